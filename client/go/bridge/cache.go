@@ -0,0 +1,94 @@
+// Package bridge implements the XPlaneBridge gRPC service on top of the
+// xplane UDP client, so datarefs can be consumed over gRPC (and, via
+// grpc-gateway, plain REST/JSON) instead of X-Plane's native UDP protocol.
+package bridge
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codeboyzhou/xplane-udp-bridge/client/go/bridge/xplanebridgepb"
+	"github.com/codeboyzhou/xplane-udp-bridge/client/go/xplane"
+)
+
+// datarefCache adapts xplane.Registry to the typed xplanebridgepb values
+// WatchDataRefs streams to callers. The registry already coalesces
+// concurrent subscribers of the same dataref into a single upstream poll;
+// this type only owns the string->typed conversion and the data type each
+// dataref was last requested as, since Registry.Subscribe does not carry
+// that through to Read.
+type datarefCache struct {
+	registry *xplane.Registry
+	reader   *xplane.DataRefReader
+}
+
+// newDatarefCache creates a cache backed by reader.
+func newDatarefCache(reader *xplane.DataRefReader) *datarefCache {
+	return &datarefCache{
+		registry: xplane.NewRegistry(reader),
+		reader:   reader,
+	}
+}
+
+// subscribe joins (or starts) the upstream poll for name/dataType at
+// rateHz and returns a channel of typed samples plus an unsubscribe
+// function, mirroring xplane.Registry.Subscribe.
+//
+// done lets the caller abandon delivery without calling the returned
+// unsubscribe function first: if nothing is reading typedSamples anymore
+// (e.g. the gRPC stream's context was canceled) but rawSamples is still
+// producing, a plain unselected send would block this goroutine forever.
+func (c *datarefCache) subscribe(done <-chan struct{}, name, dataType string, rateHz int32) (<-chan xplanebridgepb.DataRefSample, func()) {
+	rawSamples, cancel := c.registry.Subscribe(name, dataType, int(rateHz))
+
+	typedSamples := make(chan xplanebridgepb.DataRefSample, 1)
+	go func() {
+		defer close(typedSamples)
+		for rawSample := range rawSamples {
+			select {
+			case typedSamples <- toDataRefSample(rawSample, dataType):
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return typedSamples, cancel
+}
+
+// readSample performs one upstream read and converts the raw string
+// response into a typed DataRefSample.
+func (c *datarefCache) readSample(name, dataType string) xplanebridgepb.DataRefSample {
+	raw := c.reader.Read(name, dataType)
+	return toDataRefSample(xplane.Sample{Dataref: name, Value: raw, At: time.Now()}, dataType)
+}
+
+// toDataRefSample converts a raw xplane.Sample into a typed
+// xplanebridgepb.DataRefSample, interpreting its string value according
+// to dataType.
+func toDataRefSample(raw xplane.Sample, dataType string) xplanebridgepb.DataRefSample {
+	value := xplanebridgepb.DataRefValue{Name: raw.Dataref}
+
+	switch {
+	case strings.HasPrefix(dataType, "["):
+		for _, part := range strings.Split(raw.Value, ",") {
+			if f, err := strconv.ParseFloat(part, 64); err == nil {
+				value.FloatArrayValue.Values = append(value.FloatArrayValue.Values, f)
+			}
+		}
+		value.Kind = xplanebridgepb.DataRefValueKindFloatArray
+	case dataType == "int":
+		value.IntValue, _ = strconv.ParseInt(raw.Value, 10, 64)
+		value.Kind = xplanebridgepb.DataRefValueKindInt
+	default:
+		value.FloatValue, _ = strconv.ParseFloat(raw.Value, 64)
+		value.Kind = xplanebridgepb.DataRefValueKindFloat
+	}
+
+	return xplanebridgepb.DataRefSample{
+		Name:              raw.Dataref,
+		Value:             value,
+		TimestampUnixNano: raw.At.UnixNano(),
+	}
+}
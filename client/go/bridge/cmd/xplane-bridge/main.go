@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"net"
+	"net/http"
+
+	"github.com/fatih/color"
+	"google.golang.org/grpc"
+
+	"github.com/codeboyzhou/xplane-udp-bridge/client/go/bridge"
+	"github.com/codeboyzhou/xplane-udp-bridge/client/go/bridge/xplanebridgepb"
+	"github.com/codeboyzhou/xplane-udp-bridge/client/go/xplane"
+)
+
+// main starts the xplane-bridge daemon: a long-running process that keeps
+// one UDP connection to X-Plane open and exposes it to any number of
+// callers over gRPC, replacing the original poll-and-print main.go loop
+// with a service other processes can depend on.
+//
+// Flags:
+//
+//	-xplane-host   X-Plane server host (default "127.0.0.1")
+//	-xplane-port   X-Plane server port (default 49000)
+//	-grpc-addr     Address the gRPC server listens on (default ":50051")
+//	-gateway-addr  Address the REST/JSON gateway listens on; empty disables it
+func main() {
+	xplaneHost := flag.String("xplane-host", "127.0.0.1", "X-Plane server host")
+	xplanePort := flag.Int("xplane-port", 49000, "X-Plane server port")
+	grpcAddr := flag.String("grpc-addr", ":50051", "address the gRPC server listens on")
+	gatewayAddr := flag.String("gateway-addr", "", "address the REST/JSON gateway listens on; empty disables it")
+	flag.Parse()
+
+	client := xplane.NewUdpClient(*xplaneHost, *xplanePort, 3)
+	reader := xplane.NewDataRefReader(client)
+	server := bridge.NewServer(reader)
+
+	grpcServer := grpc.NewServer()
+	xplanebridgepb.RegisterXPlaneBridgeServer(grpcServer, server)
+
+	listener, err := net.Listen("tcp", *grpcAddr)
+	if err != nil {
+		color.Red("xplane-bridge: failed to listen on %s: %v\n", *grpcAddr, err)
+		return
+	}
+
+	if *gatewayAddr != "" {
+		go runGateway(*gatewayAddr, *grpcAddr)
+	}
+
+	color.Green("xplane-bridge: serving gRPC on %s\n", *grpcAddr)
+	if err := grpcServer.Serve(listener); err != nil {
+		color.Red("xplane-bridge: gRPC server stopped: %v\n", err)
+	}
+}
+
+// runGateway starts a grpc-gateway HTTP server that translates REST/JSON
+// requests into calls against the gRPC server listening on grpcAddr, so
+// browser or curl clients can read datarefs without a gRPC client.
+func runGateway(gatewayAddr, grpcAddr string) {
+	ctx := context.Background()
+	mux := http.NewServeMux()
+
+	if err := xplanebridgepb.RegisterXPlaneBridgeHandlerFromEndpoint(ctx, mux, grpcAddr); err != nil {
+		color.Red("xplane-bridge: failed to start REST gateway: %v\n", err)
+		return
+	}
+
+	color.Green("xplane-bridge: serving REST gateway on %s\n", gatewayAddr)
+	if err := http.ListenAndServe(gatewayAddr, mux); err != nil {
+		color.Red("xplane-bridge: REST gateway stopped: %v\n", err)
+	}
+}
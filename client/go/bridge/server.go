@@ -0,0 +1,101 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/codeboyzhou/xplane-udp-bridge/client/go/bridge/xplanebridgepb"
+	"github.com/codeboyzhou/xplane-udp-bridge/client/go/xplane"
+)
+
+// Server implements xplanebridgepb.XPlaneBridgeServer on top of a single
+// shared xplane.DataRefReader. All RPCs ultimately go through the same
+// UDP connection to X-Plane; WatchDataRefs additionally coalesces
+// concurrent watchers of the same dataref through a datarefCache so they
+// share one upstream poll instead of one each.
+type Server struct {
+	xplanebridgepb.UnimplementedXPlaneBridgeServer
+	reader *xplane.DataRefReader
+	cache  *datarefCache
+}
+
+// NewServer creates a Server that reads datarefs through reader.
+func NewServer(reader *xplane.DataRefReader) *Server {
+	return &Server{
+		reader: reader,
+		cache:  newDatarefCache(reader),
+	}
+}
+
+// ReadDataRef issues a single dataref read and returns its typed value.
+func (s *Server) ReadDataRef(_ context.Context, req *xplanebridgepb.ReadDataRefRequest) (*xplanebridgepb.DataRefValue, error) {
+	sample := s.cache.readSample(req.Name, req.DataType)
+	return &sample.Value, nil
+}
+
+// BatchReadDataRefs reads every requested dataref and returns the values
+// in the same order as the requests.
+func (s *Server) BatchReadDataRefs(ctx context.Context, req *xplanebridgepb.BatchReadDataRefsRequest) (*xplanebridgepb.BatchReadDataRefsResponse, error) {
+	values := make([]xplanebridgepb.DataRefValue, len(req.Requests))
+	for i, r := range req.Requests {
+		value, err := s.ReadDataRef(ctx, &r)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = *value
+	}
+	return &xplanebridgepb.BatchReadDataRefsResponse{Values: values}, nil
+}
+
+// WatchDataRefs subscribes to each dataref the caller requests on the
+// stream and pushes samples back as they are polled. A caller may send
+// multiple WatchDataRefsRequest messages to add further datarefs to the
+// same stream; the stream ends when the caller disconnects or the context
+// is canceled.
+func (s *Server) WatchDataRefs(stream xplanebridgepb.XPlaneBridgeWatchDataRefsServer) error {
+	ctx := stream.Context()
+	samples := make(chan xplanebridgepb.DataRefSample)
+
+	go func() {
+		var unsubscribers []func()
+		defer func() {
+			for _, unsubscribe := range unsubscribers {
+				unsubscribe()
+			}
+		}()
+
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			if req.RateHz <= 0 {
+				continue
+			}
+
+			ch, unsubscribe := s.cache.subscribe(ctx.Done(), req.Name, req.DataType, req.RateHz)
+			unsubscribers = append(unsubscribers, unsubscribe)
+
+			go func(ch <-chan xplanebridgepb.DataRefSample) {
+				for sample := range ch {
+					select {
+					case samples <- sample:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}(ch)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case sample := <-samples:
+			if err := stream.Send(&sample); err != nil {
+				return fmt.Errorf("xplanebridge: send sample: %w", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// See the note at the top of xplanebridge.go: this stands in for
+// protoc-gen-go-grpc's client-side output.
+package xplanebridgepb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// XPlaneBridgeClient is the client-side stub for the XPlaneBridge service.
+type XPlaneBridgeClient interface {
+	ReadDataRef(ctx context.Context, req *ReadDataRefRequest, opts ...grpc.CallOption) (*DataRefValue, error)
+	BatchReadDataRefs(ctx context.Context, req *BatchReadDataRefsRequest, opts ...grpc.CallOption) (*BatchReadDataRefsResponse, error)
+}
+
+type xPlaneBridgeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewXPlaneBridgeClient creates a client bound to cc.
+func NewXPlaneBridgeClient(cc grpc.ClientConnInterface) XPlaneBridgeClient {
+	return &xPlaneBridgeClient{cc: cc}
+}
+
+func (c *xPlaneBridgeClient) ReadDataRef(ctx context.Context, req *ReadDataRefRequest, opts ...grpc.CallOption) (*DataRefValue, error) {
+	resp := new(DataRefValue)
+	if err := c.cc.Invoke(ctx, "/xplanebridge.XPlaneBridge/ReadDataRef", req, resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *xPlaneBridgeClient) BatchReadDataRefs(ctx context.Context, req *BatchReadDataRefsRequest, opts ...grpc.CallOption) (*BatchReadDataRefsResponse, error) {
+	resp := new(BatchReadDataRefsResponse)
+	if err := c.cc.Invoke(ctx, "/xplanebridge.XPlaneBridge/BatchReadDataRefs", req, resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
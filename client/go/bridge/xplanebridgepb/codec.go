@@ -0,0 +1,40 @@
+package xplanebridgepb
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// jsonCodec marshals gRPC messages as JSON instead of protobuf wire
+// format. grpc-go's default "proto" codec calls proto.Marshal, which
+// requires a real proto.Message (ProtoReflect()); the request/response
+// types in this package are plain hand-written structs standing in for
+// protoc-gen-go output and don't implement that interface. Registering
+// this codec under the "proto" name makes every RPC in this process use
+// JSON instead, which works against any exported-field struct.
+//
+// This is a stand-in for real protobuf encoding, same as the rest of this
+// package; once it is regenerated from actual .proto-derived types, this
+// file can be deleted and the default "proto" codec used as intended.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return "proto"
+}
+
+// init registers jsonCodec in place of grpc-go's default "proto" codec.
+// Both XPlaneBridgeServer and XPlaneBridgeClient go through this codec
+// since registration is process-wide: any binary that imports this
+// package to either serve or call the service gets it.
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
@@ -0,0 +1,95 @@
+// See the note at the top of xplanebridge.go: this stands in for
+// protoc-gen-grpc-gateway's output. protoc-gen-grpc-gateway's real
+// runtime.ForwardResponseMessage requires a proto.Message (it calls
+// ProtoReflect() on the response), which the hand-written types in this
+// package do not implement. Rather than depend on that, responses here
+// are encoded with plain encoding/json; once this package is regenerated
+// from real proto messages, it can switch back to grpc-gateway's
+// marshaler and drop the json import.
+package xplanebridgepb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// registerXPlaneBridgeHandler dials grpcEndpoint and maps the unary
+// XPlaneBridge RPCs onto REST routes:
+//
+//	GET /v1/dataref/{name}?data_type=float       -> ReadDataRef
+//	POST /v1/datarefs:batchRead                  -> BatchReadDataRefs
+//
+// WatchDataRefs is a bidirectional stream and has no REST mapping here;
+// callers that need it use gRPC directly.
+func registerXPlaneBridgeHandler(ctx context.Context, mux *http.ServeMux, grpcEndpoint string) error {
+	conn, err := grpc.Dial(grpcEndpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	client := NewXPlaneBridgeClient(conn)
+
+	mux.HandleFunc("/v1/dataref/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		name := strings.TrimPrefix(r.URL.Path, "/v1/dataref/")
+		if name == "" {
+			http.Error(w, "missing dataref name", http.StatusBadRequest)
+			return
+		}
+
+		req := &ReadDataRefRequest{
+			Name:     name,
+			DataType: r.URL.Query().Get("data_type"),
+		}
+		value, err := client.ReadDataRef(ctx, req)
+		if err != nil {
+			writeJSONError(w, err)
+			return
+		}
+		writeJSON(w, value)
+	})
+
+	mux.HandleFunc("/v1/datarefs:batchRead", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req BatchReadDataRefsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSONError(w, err)
+			return
+		}
+		resp, err := client.BatchReadDataRefs(ctx, &req)
+		if err != nil {
+			writeJSONError(w, err)
+			return
+		}
+		writeJSON(w, resp)
+	})
+
+	return nil
+}
+
+// writeJSON encodes v as the response body with a JSON content type.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeJSONError reports err as a JSON body with a 500 status. The gRPC
+// calls this gateway makes do not carry status codes in this hand-written
+// client, so every failure is reported as an internal error.
+func writeJSONError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusInternalServerError)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,96 @@
+// This file stands in for the *_grpc.pb.go output of protoc-gen-go-grpc
+// and the *.pb.gw.go output of protoc-gen-grpc-gateway. Regenerate and
+// remove it once the protoc toolchain is wired into the build; see the
+// note at the top of xplanebridge.go.
+package xplanebridgepb
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+)
+
+// RegisterXPlaneBridgeServer registers srv as the handler for the
+// XPlaneBridge service on the given gRPC server.
+func RegisterXPlaneBridgeServer(s grpc.ServiceRegistrar, srv XPlaneBridgeServer) {
+	s.RegisterService(&xPlaneBridgeServiceDesc, srv)
+}
+
+// xPlaneBridgeServiceDesc declares the XPlaneBridge RPC methods to the
+// gRPC runtime, mirroring the ServiceDesc protoc-gen-go-grpc would emit.
+var xPlaneBridgeServiceDesc = grpc.ServiceDesc{
+	ServiceName: "xplanebridge.XPlaneBridge",
+	HandlerType: (*XPlaneBridgeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReadDataRef",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := new(ReadDataRefRequest)
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(XPlaneBridgeServer).ReadDataRef(ctx, req)
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/xplanebridge.XPlaneBridge/ReadDataRef"}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(XPlaneBridgeServer).ReadDataRef(ctx, req.(*ReadDataRefRequest))
+				}
+				return interceptor(ctx, req, info, handler)
+			},
+		},
+		{
+			MethodName: "BatchReadDataRefs",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := new(BatchReadDataRefsRequest)
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(XPlaneBridgeServer).BatchReadDataRefs(ctx, req)
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/xplanebridge.XPlaneBridge/BatchReadDataRefs"}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(XPlaneBridgeServer).BatchReadDataRefs(ctx, req.(*BatchReadDataRefsRequest))
+				}
+				return interceptor(ctx, req, info, handler)
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchDataRefs",
+			ServerStreams: true,
+			ClientStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				return srv.(XPlaneBridgeServer).WatchDataRefs(&watchDataRefsServerStream{stream})
+			},
+		},
+	},
+}
+
+// watchDataRefsServerStream adapts a grpc.ServerStream to
+// XPlaneBridgeWatchDataRefsServer.
+type watchDataRefsServerStream struct {
+	grpc.ServerStream
+}
+
+func (s *watchDataRefsServerStream) Send(sample *DataRefSample) error {
+	return s.ServerStream.SendMsg(sample)
+}
+
+func (s *watchDataRefsServerStream) Recv() (*WatchDataRefsRequest, error) {
+	req := new(WatchDataRefsRequest)
+	if err := s.ServerStream.RecvMsg(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// RegisterXPlaneBridgeHandlerFromEndpoint wires an http.ServeMux up to a
+// running XPlaneBridge gRPC server reachable at grpcEndpoint, so the
+// unary RPCs can also be called as plain REST/JSON over HTTP.
+func RegisterXPlaneBridgeHandlerFromEndpoint(ctx context.Context, mux *http.ServeMux, grpcEndpoint string) error {
+	return registerXPlaneBridgeHandler(ctx, mux, grpcEndpoint)
+}
@@ -0,0 +1,105 @@
+// Package xplanebridgepb contains the message and service types described
+// by proto/xplane_bridge.proto. In a normal build these would be generated
+// by protoc-gen-go and protoc-gen-go-grpc; they are hand-written here so
+// the bridge package has something concrete to implement against. Running
+//
+//	protoc --go_out=. --go-grpc_out=. proto/xplane_bridge.proto
+//
+// regenerates this file from the source of truth and should replace it
+// once the protoc toolchain is wired into the build.
+package xplanebridgepb
+
+import "context"
+
+// ReadDataRefRequest asks for a single dataref's current value.
+type ReadDataRefRequest struct {
+	Name     string
+	DataType string
+}
+
+// FloatArray carries an array-typed dataref value.
+type FloatArray struct {
+	Values []float64
+}
+
+// DataRefValue is the typed result of a dataref read. Exactly one of
+// FloatValue, IntValue, or FloatArrayValue is meaningful, selected by Kind.
+type DataRefValue struct {
+	Name            string
+	Kind            DataRefValueKind
+	FloatValue      float64
+	IntValue        int64
+	FloatArrayValue FloatArray
+}
+
+// DataRefValueKind identifies which field of DataRefValue holds data.
+type DataRefValueKind int
+
+const (
+	DataRefValueKindFloat DataRefValueKind = iota
+	DataRefValueKindInt
+	DataRefValueKindFloatArray
+)
+
+// BatchReadDataRefsRequest reads several datarefs in one round trip.
+type BatchReadDataRefsRequest struct {
+	Requests []ReadDataRefRequest
+}
+
+// BatchReadDataRefsResponse holds one value per request, in request order.
+type BatchReadDataRefsResponse struct {
+	Values []DataRefValue
+}
+
+// WatchDataRefsRequest subscribes to a dataref at a caller-specified rate.
+type WatchDataRefsRequest struct {
+	Name     string
+	DataType string
+	RateHz   int32
+}
+
+// DataRefSample is a single polled value pushed to a watcher.
+type DataRefSample struct {
+	Name              string
+	Value             DataRefValue
+	TimestampUnixNano int64
+}
+
+// XPlaneBridgeWatchDataRefsServer is the server-side handle for the
+// streaming WatchDataRefs RPC, mirroring the interface protoc-gen-go-grpc
+// would generate for a bidirectional stream.
+type XPlaneBridgeWatchDataRefsServer interface {
+	Send(*DataRefSample) error
+	Recv() (*WatchDataRefsRequest, error)
+	Context() context.Context
+}
+
+// XPlaneBridgeServer is the service interface bridge.Server implements.
+type XPlaneBridgeServer interface {
+	ReadDataRef(context.Context, *ReadDataRefRequest) (*DataRefValue, error)
+	BatchReadDataRefs(context.Context, *BatchReadDataRefsRequest) (*BatchReadDataRefsResponse, error)
+	WatchDataRefs(XPlaneBridgeWatchDataRefsServer) error
+}
+
+// UnimplementedXPlaneBridgeServer can be embedded to satisfy
+// XPlaneBridgeServer while only overriding the methods a server cares
+// about, the same forward-compatibility convention protoc-gen-go-grpc uses.
+type UnimplementedXPlaneBridgeServer struct{}
+
+func (UnimplementedXPlaneBridgeServer) ReadDataRef(context.Context, *ReadDataRefRequest) (*DataRefValue, error) {
+	return nil, errUnimplemented("ReadDataRef")
+}
+
+func (UnimplementedXPlaneBridgeServer) BatchReadDataRefs(context.Context, *BatchReadDataRefsRequest) (*BatchReadDataRefsResponse, error) {
+	return nil, errUnimplemented("BatchReadDataRefs")
+}
+
+func (UnimplementedXPlaneBridgeServer) WatchDataRefs(XPlaneBridgeWatchDataRefsServer) error {
+	return errUnimplemented("WatchDataRefs")
+}
+
+type unimplementedError string
+
+func (e unimplementedError) Error() string { return "xplanebridgepb: " + string(e) + " not implemented" }
+
+func errUnimplemented(method string) error { return unimplementedError(method) }
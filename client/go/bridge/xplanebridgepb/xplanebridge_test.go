@@ -0,0 +1,99 @@
+package xplanebridgepb
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// stubServer answers ReadDataRef/BatchReadDataRefs with canned values, so
+// the tests below can assert a round trip through a real grpc.Server and
+// grpc.ClientConn actually succeeds, rather than just compiling.
+type stubServer struct {
+	UnimplementedXPlaneBridgeServer
+}
+
+func (stubServer) ReadDataRef(_ context.Context, req *ReadDataRefRequest) (*DataRefValue, error) {
+	return &DataRefValue{
+		Name:       req.Name,
+		Kind:       DataRefValueKindFloat,
+		FloatValue: 42.5,
+	}, nil
+}
+
+func (s stubServer) BatchReadDataRefs(ctx context.Context, req *BatchReadDataRefsRequest) (*BatchReadDataRefsResponse, error) {
+	values := make([]DataRefValue, len(req.Requests))
+	for i, r := range req.Requests {
+		value, err := s.ReadDataRef(ctx, &r)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = *value
+	}
+	return &BatchReadDataRefsResponse{Values: values}, nil
+}
+
+// dialStubServer starts a real grpc.Server backed by stubServer on a
+// loopback listener and returns a connected client plus a stop function.
+func dialStubServer(t *testing.T) (client XPlaneBridgeClient, stop func()) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	server := grpc.NewServer()
+	RegisterXPlaneBridgeServer(server, stubServer{})
+	go server.Serve(listener)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		server.Stop()
+		_ = listener.Close()
+		t.Fatalf("failed to dial: %v", err)
+	}
+
+	return NewXPlaneBridgeClient(conn), func() {
+		_ = conn.Close()
+		server.Stop()
+	}
+}
+
+// TestReadDataRefRoundTrip proves ReadDataRef actually completes end to
+// end: without the jsonCodec in codec.go, grpc-go's default codec rejects
+// these hand-written structs with "message is not a proto.Message".
+func TestReadDataRefRoundTrip(t *testing.T) {
+	client, stop := dialStubServer(t)
+	defer stop()
+
+	value, err := client.ReadDataRef(context.Background(), &ReadDataRefRequest{Name: "sim/test/dataref", DataType: "float"})
+	if err != nil {
+		t.Fatalf("ReadDataRef failed: %v", err)
+	}
+	if value.Name != "sim/test/dataref" || value.Kind != DataRefValueKindFloat || value.FloatValue != 42.5 {
+		t.Fatalf("unexpected value: %+v", value)
+	}
+}
+
+func TestBatchReadDataRefsRoundTrip(t *testing.T) {
+	client, stop := dialStubServer(t)
+	defer stop()
+
+	req := &BatchReadDataRefsRequest{
+		Requests: []ReadDataRefRequest{
+			{Name: "a", DataType: "float"},
+			{Name: "b", DataType: "float"},
+		},
+	}
+	batch, err := client.BatchReadDataRefs(context.Background(), req)
+	if err != nil {
+		t.Fatalf("BatchReadDataRefs failed: %v", err)
+	}
+	if len(batch.Values) != 2 || batch.Values[0].Name != "a" || batch.Values[1].Name != "b" {
+		t.Fatalf("unexpected batch result: %+v", batch.Values)
+	}
+}
@@ -0,0 +1,363 @@
+// Package main implements a UDP bridge client for X-Plane data references.
+// This module adds a benchmark subsystem that runs a configurable number
+// of worker goroutines against a shared DataRefReader, records per-request
+// latency in a fixed-size ring buffer per worker, and aggregates the
+// results into percentile and throughput statistics once every worker
+// finishes.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/codeboyzhou/xplane-udp-bridge/client/go/xplane"
+)
+
+// BenchmarkType identifies which request suite a benchmark run exercises.
+type BenchmarkType string
+
+const (
+	// BenchmarkTypeRead drives dataref read requests.
+	BenchmarkTypeRead BenchmarkType = "read"
+	// BenchmarkTypeSet drives dataref write requests via WriteFloat.
+	BenchmarkTypeSet BenchmarkType = "set"
+)
+
+// BenchmarkConfig describes the load a benchmark run should generate. The
+// per-request timeout is not part of this config: it is set once when the
+// underlying Transport is constructed, the same as for any other caller.
+type BenchmarkConfig struct {
+	Type              BenchmarkType // Which suite to run: "read" or "set".
+	Datarefs          []string      // Datarefs to cycle through, round-robin per worker.
+	Concurrency       int           // Number of concurrent virtual clients (goroutines).
+	RequestsPerClient int           // Number of requests each virtual client issues.
+	Warmup            time.Duration // Duration of unmeasured warmup traffic before sampling begins.
+}
+
+// latencyRing is a fixed-capacity, per-worker ring buffer of latency samples.
+// Each benchmark worker owns exactly one ring and never shares it with
+// another goroutine, so no locking is required while a run is in flight.
+// Once the ring is full, new samples overwrite the oldest ones, which
+// bounds memory use for long-running benchmarks at the cost of an
+// approximate (rather than exact) percentile estimate.
+type latencyRing struct {
+	samples []time.Duration
+	next    int
+	filled  bool
+}
+
+// newLatencyRing creates a ring with the given sample capacity.
+func newLatencyRing(capacity int) *latencyRing {
+	return &latencyRing{samples: make([]time.Duration, capacity)}
+}
+
+// add records a single latency sample, overwriting the oldest entry once
+// the ring has reached capacity.
+func (r *latencyRing) add(d time.Duration) {
+	r.samples[r.next] = d
+	r.next = (r.next + 1) % len(r.samples)
+	if r.next == 0 {
+		r.filled = true
+	}
+}
+
+// values returns the samples currently held by the ring.
+func (r *latencyRing) values() []time.Duration {
+	if r.filled {
+		return r.samples
+	}
+	return r.samples[:r.next]
+}
+
+// workerResult is the per-worker outcome handed back to the aggregator.
+type workerResult struct {
+	success   int
+	failure   int
+	latencies []time.Duration
+}
+
+// BenchmarkReport summarizes the outcome of a benchmark run.
+type BenchmarkReport struct {
+	Type             BenchmarkType `json:"type"`
+	TotalMessages    int           `json:"total_messages"`
+	SuccessCount     int           `json:"success_count"`
+	FailureCount     int           `json:"failure_count"`
+	Duration         time.Duration `json:"duration_ns"`
+	ThroughputPerSec float64       `json:"throughput_msgs_per_sec"`
+	P50              time.Duration `json:"p50_ns"`
+	P90              time.Duration `json:"p90_ns"`
+	P99              time.Duration `json:"p99_ns"`
+	AvgLatency       time.Duration `json:"avg_latency_ns"`
+	JitterStddev     time.Duration `json:"jitter_stddev_ns"`
+	// PeakRSSBytes is the highest resident set size observed during the
+	// run (see readRSSBytes), not the total memory reserved from the OS.
+	PeakRSSBytes uint64 `json:"peak_rss_bytes"`
+	Note         string `json:"note,omitempty"`
+}
+
+// samplesPerWorker bounds how many latency samples each worker ring keeps,
+// regardless of how many requests the worker issues over the run.
+const samplesPerWorker = 10000
+
+// RunBenchmark drives load described by cfg against the endpoint reader is
+// connected to, and returns an aggregated report. cfg.Type selects whether
+// each request is a dataref read or a dataref write.
+//
+// Each virtual client runs as its own goroutine issuing
+// cfg.RequestsPerClient requests against a round-robin selection of
+// cfg.Datarefs. If reader's Transport supports Dialer, every worker gets
+// its own dialed connection and a dedicated DataRefReader, so concurrent
+// workers actually overlap in flight instead of serializing on one
+// connection's mutex; otherwise every worker shares reader, and raising
+// cfg.Concurrency mostly adds scheduler contention rather than concurrent
+// load. Requests issued during the cfg.Warmup window are executed but
+// excluded from the reported statistics, so the numbers reflect steady
+// state rather than connection/cache warmup effects.
+func RunBenchmark(reader *xplane.DataRefReader, cfg BenchmarkConfig) *BenchmarkReport {
+	issueRequest, err := requestFuncFor(cfg.Type)
+	if err != nil {
+		color.Yellow("%v\n", err)
+		return &BenchmarkReport{Type: cfg.Type, Note: err.Error()}
+	}
+
+	peakRSS, stopRSSSampler := startPeakRSSSampler()
+	defer stopRSSSampler()
+
+	results := make(chan workerResult, cfg.Concurrency)
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	warmupDeadline := start.Add(cfg.Warmup)
+
+	for worker := 0; worker < cfg.Concurrency; worker++ {
+		workerReader := dialWorkerReader(reader)
+
+		wg.Add(1)
+		go func(worker int, reader *xplane.DataRefReader) {
+			defer wg.Done()
+			ring := newLatencyRing(samplesPerWorker)
+			result := workerResult{}
+
+			for i := 0; i < cfg.RequestsPerClient; i++ {
+				dataref := cfg.Datarefs[(worker+i)%len(cfg.Datarefs)]
+
+				reqStart := time.Now()
+				ok := issueRequest(reader, dataref, i)
+				latency := time.Since(reqStart)
+
+				if ok {
+					result.success++
+				} else {
+					result.failure++
+				}
+
+				if reqStart.After(warmupDeadline) {
+					ring.add(latency)
+				}
+			}
+
+			result.latencies = ring.values()
+			results <- result
+		}(worker, workerReader)
+	}
+
+	wg.Wait()
+	close(results)
+	duration := time.Since(start)
+
+	return aggregateResults(cfg.Type, duration, results, peakRSS())
+}
+
+// dialWorkerReader returns a DataRefReader for a single benchmark worker to
+// use. When reader's Transport supports Dialer, it opens a second,
+// independent connection to the same endpoint so this worker's requests
+// don't serialize behind every other worker's on one connection's mutex;
+// otherwise it falls back to the shared reader.
+func dialWorkerReader(reader *xplane.DataRefReader) *xplane.DataRefReader {
+	dialer, ok := reader.Transport().(xplane.Dialer)
+	if !ok {
+		return reader
+	}
+
+	conn, err := dialer.Dial()
+	if err != nil {
+		color.Yellow("Failed to dial a dedicated benchmark connection, falling back to the shared one: %v\n", err)
+		return reader
+	}
+
+	return xplane.NewDataRefReader(conn)
+}
+
+// requestFuncFor returns the per-request action a worker should issue for
+// benchType, or an error if benchType is not recognized.
+func requestFuncFor(benchType BenchmarkType) (func(reader *xplane.DataRefReader, dataref string, i int) bool, error) {
+	switch benchType {
+	case BenchmarkTypeRead:
+		return func(reader *xplane.DataRefReader, dataref string, i int) bool {
+			return reader.Read(dataref, "float") != ""
+		}, nil
+	case BenchmarkTypeSet:
+		return func(reader *xplane.DataRefReader, dataref string, i int) bool {
+			return reader.WriteFloat(dataref, float32(i%1000)/1000) == nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown benchmark type %q", benchType)
+	}
+}
+
+// aggregateResults merges per-worker outcomes into a single report.
+func aggregateResults(benchType BenchmarkType, duration time.Duration, results <-chan workerResult, peakRSSBytes uint64) *BenchmarkReport {
+	var allLatencies []time.Duration
+	report := &BenchmarkReport{Type: benchType, Duration: duration, PeakRSSBytes: peakRSSBytes}
+
+	for result := range results {
+		report.SuccessCount += result.success
+		report.FailureCount += result.failure
+		allLatencies = append(allLatencies, result.latencies...)
+	}
+
+	report.TotalMessages = report.SuccessCount + report.FailureCount
+	if duration > 0 {
+		report.ThroughputPerSec = float64(report.TotalMessages) / duration.Seconds()
+	}
+
+	if len(allLatencies) == 0 {
+		return report
+	}
+
+	sort.Slice(allLatencies, func(i, j int) bool { return allLatencies[i] < allLatencies[j] })
+	report.P50 = percentile(allLatencies, 0.50)
+	report.P90 = percentile(allLatencies, 0.90)
+	report.P99 = percentile(allLatencies, 0.99)
+	report.AvgLatency, report.JitterStddev = meanAndStddev(allLatencies)
+
+	return report
+}
+
+// percentile returns the value at the given rank (0-1) of a sorted sample.
+func percentile(sorted []time.Duration, rank float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := int(rank * float64(len(sorted)-1))
+	return sorted[index]
+}
+
+// meanAndStddev returns the average latency and its standard deviation,
+// which this package reports as jitter.
+func meanAndStddev(samples []time.Duration) (time.Duration, time.Duration) {
+	var sum float64
+	for _, s := range samples {
+		sum += float64(s)
+	}
+	mean := sum / float64(len(samples))
+
+	var variance float64
+	for _, s := range samples {
+		diff := float64(s) - mean
+		variance += diff * diff
+	}
+	variance /= float64(len(samples))
+
+	return time.Duration(mean), time.Duration(math.Sqrt(variance))
+}
+
+// startPeakRSSSampler periodically samples the process's resident set size
+// and returns an accessor for the peak value observed, along with a stop
+// function that must be called to release the sampling goroutine.
+func startPeakRSSSampler() (peak func() uint64, stop func()) {
+	var mu sync.Mutex
+	var peakBytes uint64
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(50 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				rss := readRSSBytes()
+				mu.Lock()
+				if rss > peakBytes {
+					peakBytes = rss
+				}
+				mu.Unlock()
+			}
+		}
+	}()
+
+	peak = func() uint64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return peakBytes
+	}
+	stop = func() { close(done) }
+	return peak, stop
+}
+
+// readRSSBytes returns the process's current resident set size in bytes,
+// read from /proc/self/status's VmRSS line. On platforms without /proc
+// (anything but Linux) it falls back to runtime.MemStats.Sys, the total
+// memory reserved from the OS, which is a looser upper bound on actual
+// physical memory use rather than RSS itself.
+func readRSSBytes() uint64 {
+	file, err := os.Open("/proc/self/status")
+	if err != nil {
+		var memStats runtime.MemStats
+		runtime.ReadMemStats(&memStats)
+		return memStats.Sys
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "VmRSS:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[2] != "kB" {
+			break
+		}
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			break
+		}
+		return kb * 1024
+	}
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	return memStats.Sys
+}
+
+// PrintBenchmarkReport renders a report as a human-readable table.
+func PrintBenchmarkReport(report *BenchmarkReport) {
+	fmt.Println()
+	color.Cyan("Benchmark report (type=%s)\n", report.Type)
+	if report.Note != "" {
+		color.Yellow("%s\n", report.Note)
+		return
+	}
+	fmt.Printf("  Total messages:    %d\n", report.TotalMessages)
+	fmt.Printf("  Success / Failure: %d / %d\n", report.SuccessCount, report.FailureCount)
+	fmt.Printf("  Duration:          %s\n", report.Duration)
+	fmt.Printf("  Throughput:        %.1f msgs/sec\n", report.ThroughputPerSec)
+	fmt.Printf("  P50 / P90 / P99:   %s / %s / %s\n", report.P50, report.P90, report.P99)
+	fmt.Printf("  Avg latency:       %s\n", report.AvgLatency)
+	fmt.Printf("  Jitter (stddev):   %s\n", report.JitterStddev)
+	fmt.Printf("  Peak RSS:          %.2f MiB\n", float64(report.PeakRSSBytes)/(1024*1024))
+}
@@ -4,41 +4,94 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
 	"time"
+
+	"github.com/codeboyzhou/xplane-udp-bridge/client/go/xplane"
 )
 
 // main is the entry point of the X-Plane UDP bridge client application.
-// It demonstrates the usage of the UDP client and DataRefReader to
-// continuously read data references from X-Plane.
-//
-// The application:
-// 1. Creates a UDP client connection to X-Plane at 127.0.0.1:49000 with a 3-second timeout
-// 2. Initializes a DataRefReader with the UDP client
-// 3. Continuously reads the parking brake ratio data reference
-// 4. Sleeps for 3 seconds between iterations to avoid server overload
+// With no subcommand it subscribes to the parking brake ratio dataref and
+// prints each sample as it arrives. Passing "benchmark" as the first
+// argument instead drives the UDP client and DataRefReader under
+// configurable load and reports latency/throughput statistics; see
+// runBenchmarkCommand for its flags.
 //
 // Note:
 //
-//	This is a demonstration application. In a production environment,
-//	you would typically implement proper error handling and graceful shutdown.
+//	This demonstration is a minimal example. In a production
+//	environment, you would typically implement proper error handling
+//	and graceful shutdown.
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "benchmark" {
+		runBenchmarkCommand(os.Args[2:])
+		return
+	}
+
 	// Create UDP client
-	client := NewUdpClient("127.0.0.1", 49000, 3)
+	client := xplane.NewUdpClient("127.0.0.1", 49000, 3)
 
 	// Create DataRefReader
-	reader := NewDataRefReader(client)
+	reader := xplane.NewDataRefReader(client)
 
-	for {
-		// Read dataref value examples
-		datarefs := []string{
-			"sim/cockpit2/controls/parking_brake_ratio",
-		}
+	// Subscribe to the parking brake ratio at 1Hz instead of polling it on
+	// a sleep loop; Subscribe shares its upstream poll with any other
+	// subscriber of the same dataref.
+	samples, cancel := reader.Subscribe("sim/cockpit2/controls/parking_brake_ratio", "float", 1)
+	defer cancel()
 
-		for _, dataref := range datarefs {
-			reader.ReadAsFloat(dataref)
-		}
+	for sample := range samples {
+		fmt.Printf("[%s] %s = %s\n", sample.At.Format(time.RFC3339), sample.Dataref, sample.Value)
+	}
+}
 
-		// Sleep for a short duration to avoid overloading the server
-		time.Sleep(time.Duration(3) * time.Second)
+// runBenchmarkCommand parses flags for the "benchmark" subcommand and runs
+// RunBenchmark against a freshly created UdpClient/DataRefReader pair.
+//
+// Flags:
+//
+//	-host          X-Plane server host (default "127.0.0.1")
+//	-port          X-Plane server port (default 49000)
+//	-type          Benchmark suite to run: "read" or "set" (default "read")
+//	-datarefs      Comma-separated list of datarefs to cycle through
+//	-concurrency   Number of concurrent virtual clients (default 10)
+//	-requests      Number of requests issued per virtual client (default 100)
+//	-timeout       Per-request timeout in seconds (default 3)
+//	-warmup        Warmup duration in seconds excluded from statistics (default 1)
+//	-json-out      Optional path to also write the report as JSON
+func runBenchmarkCommand(args []string) {
+	flags := flag.NewFlagSet("benchmark", flag.ExitOnError)
+	host := flags.String("host", "127.0.0.1", "X-Plane server host")
+	port := flags.Int("port", 49000, "X-Plane server port")
+	benchType := flags.String("type", "read", "benchmark suite: read or set")
+	datarefsFlag := flags.String("datarefs", "sim/cockpit2/controls/parking_brake_ratio", "comma-separated datarefs to cycle through")
+	concurrency := flags.Int("concurrency", 10, "number of concurrent virtual clients")
+	requests := flags.Int("requests", 100, "number of requests per virtual client")
+	timeoutSecs := flags.Int("timeout", 3, "per-request timeout in seconds")
+	warmupSecs := flags.Int("warmup", 1, "warmup duration in seconds, excluded from statistics")
+	jsonOut := flags.String("json-out", "", "optional path to write the report as JSON")
+	_ = flags.Parse(args)
+
+	client := xplane.NewUdpClient(*host, *port, *timeoutSecs)
+	reader := xplane.NewDataRefReader(client)
+
+	cfg := BenchmarkConfig{
+		Type:              BenchmarkType(*benchType),
+		Datarefs:          strings.Split(*datarefsFlag, ","),
+		Concurrency:       *concurrency,
+		RequestsPerClient: *requests,
+		Warmup:            time.Duration(*warmupSecs) * time.Second,
+	}
+
+	report := RunBenchmark(reader, cfg)
+	PrintBenchmarkReport(report)
+
+	if *jsonOut != "" {
+		if err := WriteBenchmarkReportJSON(report, *jsonOut); err != nil {
+			panic(err)
+		}
 	}
 }
@@ -0,0 +1,25 @@
+// Package main implements a UDP bridge client for X-Plane data references.
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/fatih/color"
+)
+
+// WriteBenchmarkReportJSON marshals a report to indented JSON and writes it
+// to path, so results from separate runs can be diffed against each other.
+func WriteBenchmarkReportJSON(report *BenchmarkReport, path string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return err
+	}
+
+	color.Green("Wrote JSON benchmark report to %s\n", path)
+	return nil
+}
@@ -0,0 +1,46 @@
+package xplane
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// NewClient creates a Transport for addr, selecting the underlying
+// implementation from its URL scheme:
+//
+//	udp://127.0.0.1:49000        -> UdpClient
+//	unixgram:///tmp/xplane.sock  -> UnixClient, bound to localSocketPath
+//
+// For the unixgram scheme, localSocketPath is the filesystem path this
+// process binds to receive replies on; it is ignored for udp.
+func NewClient(addr, localSocketPath string, timeoutSecs int) (Transport, error) {
+	parsed, err := url.Parse(addr)
+	if err != nil {
+		return nil, fmt.Errorf("xplane: invalid address %q: %w", addr, err)
+	}
+
+	switch parsed.Scheme {
+	case "udp":
+		port, err := strconv.Atoi(parsed.Port())
+		if err != nil {
+			return nil, fmt.Errorf("xplane: invalid UDP port in %q: %w", addr, err)
+		}
+
+		client := NewUdpClient(parsed.Hostname(), port, timeoutSecs)
+		if client == nil {
+			return nil, fmt.Errorf("xplane: failed to create UDP client for %q", addr)
+		}
+		return client, nil
+
+	case "unixgram":
+		client := NewUnixClient(localSocketPath, parsed.Path, timeoutSecs)
+		if client == nil {
+			return nil, fmt.Errorf("xplane: failed to create Unix datagram client for %q", addr)
+		}
+		return client, nil
+
+	default:
+		return nil, fmt.Errorf("xplane: unsupported transport scheme %q in address %q", parsed.Scheme, addr)
+	}
+}
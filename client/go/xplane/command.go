@@ -0,0 +1,48 @@
+package xplane
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// CommandSender issues X-Plane commands over a Transport, following the
+// UDP protocol format "cmd|once|{name}".
+//
+// Unlike DataRefReader, most X-Plane commands do not acknowledge, so Send
+// is fire-and-forget rather than waiting on a read that would just time
+// out.
+type CommandSender struct {
+	client Transport
+}
+
+// NewCommandSender creates a CommandSender that issues commands over
+// client.
+func NewCommandSender(client Transport) *CommandSender {
+	return &CommandSender{client: client}
+}
+
+// Send issues cmd as a one-shot X-Plane command.
+//
+// It returns a *RequestError if cmd is empty or the transport could not
+// accept the request; it does not wait for or validate an acknowledgement,
+// since X-Plane does not send one for most commands.
+func (sender *CommandSender) Send(cmd string) error {
+	if strings.TrimSpace(cmd) == "" {
+		return &RequestError{Kind: ErrKindValidation, Op: "SendCommand", Target: cmd, Err: errors.New("command name must not be empty")}
+	}
+
+	data := fmt.Sprintf("cmd|once|%s", cmd)
+
+	fmt.Println(strings.Repeat("=", 100))
+	color.Cyan("Sending command: %s\n", data)
+
+	if err := sender.client.Send([]byte(data)); err != nil {
+		color.Red("Command %s failed: %v\n", cmd, err)
+		return &RequestError{Kind: classifyTransportErr(err), Op: "SendCommand", Target: cmd, Err: err}
+	}
+
+	return nil
+}
@@ -1,12 +1,11 @@
-// Package main implements a UDP bridge client for X-Plane data references.
-// This module provides functionality to read data references from X-Plane
-// through UDP communication, enabling external applications to access
-// flight simulator data in real-time.
-package main
+// Package xplane provides a reusable client for communicating with X-Plane
+// over its UDP dataref protocol.
+package xplane
 
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/fatih/color"
 )
@@ -20,7 +19,10 @@ import (
 // or instrument readings. This reader enables external applications to
 // monitor these values in real-time.
 type DataRefReader struct {
-	client *UdpClient // UDP client for communication with X-Plane
+	client Transport // Transport used to communicate with X-Plane, e.g. UdpClient or UnixClient
+
+	registryOnce sync.Once
+	registry     *Registry // Lazily created the first time Subscribe is called
 }
 
 // NewDataRefReader creates a new DataRefReader instance with the provided UDP client.
@@ -30,8 +32,8 @@ type DataRefReader struct {
 // must already be initialized and connected to X-Plane before creating the reader.
 //
 // Parameters:
-//   - client: A pointer to an initialized UdpClient that will be used for communication.
-//     The client should be configured to connect to X-Plane's UDP interface.
+//   - client: A Transport (UdpClient or UnixClient) that will be used for communication.
+//     The client should already be connected to X-Plane before creating the reader.
 //
 // Returns:
 //   - *DataRefReader: A pointer to the newly created DataRefReader instance.
@@ -40,20 +42,29 @@ type DataRefReader struct {
 // Example:
 //
 //	// First create and connect the UDP client
-//	client := NewUdpClient("127.0.0.1", 49000, 5)
+//	client := xplane.NewUdpClient("127.0.0.1", 49000, 5)
 //	if client == nil {
 //	    log.Fatal("Failed to create UDP client")
 //	}
 //
 //	// Then create the dataref reader
-//	reader := NewDataRefReader(client)
+//	reader := xplane.NewDataRefReader(client)
 //	value := reader.Read("sim/cockpit2/controls/parking_brake_ratio", "float")
-func NewDataRefReader(client *UdpClient) *DataRefReader {
+func NewDataRefReader(client Transport) *DataRefReader {
 	return &DataRefReader{
 		client: client,
 	}
 }
 
+// Transport returns the underlying connection this reader reads through.
+// Most callers don't need it, since DataRefReader's methods already cover
+// the request/response protocol; it exists for callers that need to
+// type-assert for a capability like Dialer (e.g. to open further
+// independent connections to the same endpoint).
+func (reader *DataRefReader) Transport() Transport {
+	return reader.client
+}
+
 // Read reads a data reference from X-Plane and returns its value as a string.
 // It formats the request, sends it via the UDP client, and parses the response.
 //
@@ -115,3 +126,32 @@ func (reader *DataRefReader) Read(dataref, dataType string) string {
 	value := strings.Split(body, "|")[2]
 	return value
 }
+
+// Subscribe delivers up to hz values per second for dataref on the returned
+// channel, until the returned CancelFunc is called.
+//
+// Subscribe shares its upstream subscription with every other subscriber of
+// the same dataref and type: the first subscriber opens it, later
+// subscribers join it (upgrading its rate if they ask for a faster one),
+// and it is torn down once the last subscriber cancels. This is the
+// replacement for the earlier `for { Read; Sleep(3s) }` pattern. See
+// Registry for how values actually reach the channel: when the underlying
+// Transport supports it, a single dedicated connection receives a
+// continuous stream of pushed updates instead of a Read per tick; when it
+// doesn't, Subscribe falls back to one ticker-driven Read per tick, shared
+// across every local subscriber of the same dataref and type.
+//
+// Parameters:
+//   - dataref: The string identifier of the X-Plane data reference to subscribe to.
+//   - dataType: The data type of the data reference, as in Read.
+//   - hz: The desired poll rate in times per second.
+//
+// Returns:
+//   - <-chan Sample: A channel of polled values. It is closed once canceled.
+//   - CancelFunc: Call this to stop this subscription. Safe to call more than once.
+func (reader *DataRefReader) Subscribe(dataref, dataType string, hz int) (<-chan Sample, CancelFunc) {
+	reader.registryOnce.Do(func() {
+		reader.registry = NewRegistry(reader)
+	})
+	return reader.registry.Subscribe(dataref, dataType, hz)
+}
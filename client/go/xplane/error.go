@@ -0,0 +1,77 @@
+package xplane
+
+import (
+	"errors"
+	"net"
+)
+
+// ErrorKind classifies why a dataref write or command send failed.
+type ErrorKind int
+
+const (
+	// ErrKindValidation means the request was rejected locally, before
+	// anything was sent to X-Plane (e.g. a non-finite float or an
+	// out-of-bounds array index).
+	ErrKindValidation ErrorKind = iota
+	// ErrKindTimeout means no response arrived before the transport's
+	// configured timeout.
+	ErrKindTimeout
+	// ErrKindTransport means the request or response could not be
+	// exchanged at all, e.g. a closed connection.
+	ErrKindTransport
+	// ErrKindMalformedResponse means X-Plane responded, but not with the
+	// acknowledgement the request expected.
+	ErrKindMalformedResponse
+)
+
+// String returns a short, lowercase name for the kind, suitable for log
+// output and error messages.
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrKindValidation:
+		return "validation"
+	case ErrKindTimeout:
+		return "timeout"
+	case ErrKindTransport:
+		return "transport"
+	case ErrKindMalformedResponse:
+		return "malformed_response"
+	default:
+		return "unknown"
+	}
+}
+
+// RequestError reports why a dataref write or command send failed. Prior
+// to its introduction, every failure in the write path was swallowed as a
+// nil or empty return, leaving callers unable to tell a timeout apart from
+// a rejected value.
+type RequestError struct {
+	Kind   ErrorKind // What category of failure this was.
+	Op     string    // The method that failed, e.g. "WriteFloat".
+	Target string    // The dataref or command name involved.
+	Err    error     // The underlying cause, if any.
+}
+
+// Error implements the error interface.
+func (e *RequestError) Error() string {
+	if e.Err != nil {
+		return "xplane: " + e.Op + " " + e.Target + ": " + e.Kind.String() + ": " + e.Err.Error()
+	}
+	return "xplane: " + e.Op + " " + e.Target + ": " + e.Kind.String()
+}
+
+// Unwrap exposes the underlying cause for errors.Is/errors.As.
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// classifyTransportErr maps a raw transport-level error to the
+// ErrorKind callers should see, pulling the timeout case out of whatever
+// net.Error the standard library returned.
+func classifyTransportErr(err error) ErrorKind {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrKindTimeout
+	}
+	return ErrKindTransport
+}
@@ -0,0 +1,291 @@
+package xplane
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// Sample is a single dataref value delivered to a subscriber.
+type Sample struct {
+	Dataref string
+	Value   string
+	At      time.Time
+}
+
+// CancelFunc stops the subscription it was returned alongside. Calling it
+// more than once is a no-op.
+type CancelFunc func()
+
+// subscriptionKey identifies one upstream subscription: one dataref of one type.
+type subscriptionKey struct {
+	dataref  string
+	dataType string
+}
+
+// subscriber is one caller's interest in a subscriptionKey, at its own
+// requested rate.
+type subscriber struct {
+	ch     chan Sample
+	rateHz int
+}
+
+// poll tracks the subscribers of one subscriptionKey and how it is being
+// serviced: either a single upstream subscription shared by all of them
+// (the common case, see Registry), or a local fallback ticker loop for
+// transports that don't support one.
+type poll struct {
+	subscribers map[int]*subscriber
+	nextID      int
+	stop        chan struct{} // closed to stop the fallback ticker loop, if running
+}
+
+// Registry owns a DataRefReader and multiplexes any number of Subscribe
+// callers for the same dataref onto a single upstream subscription. It
+// tracks a reference count per (dataref, rate) interest, upgrades or
+// downgrades the rate it has told X-Plane about as subscribers at
+// different rates come and go, and unsubscribes upstream once the last
+// subscriber cancels.
+//
+// When the underlying Transport supports it (see Dialer and RawReceiver),
+// Registry opens one dedicated connection for all subscriptions, sends a
+// single "dataref|subscribe|..." request per key, and multiplexes every
+// inbound "dataref|update|..." packet read off that connection to the
+// matching subscribers, without issuing a read request per tick. Transports
+// that don't implement those interfaces fall back to a per-key ticker loop
+// that calls DataRefReader.Read, which still coalesces concurrent local
+// subscribers of the same key onto one goroutine but pays a round trip per
+// tick.
+type Registry struct {
+	mu     sync.Mutex
+	reader *DataRefReader
+	polls  map[subscriptionKey]*poll
+
+	subConnOnce sync.Once
+	subConn     Transport // dedicated connection for push subscriptions, nil if unsupported
+}
+
+// NewRegistry creates a Registry that reads datarefs through reader.
+func NewRegistry(reader *DataRefReader) *Registry {
+	return &Registry{
+		reader: reader,
+		polls:  make(map[subscriptionKey]*poll),
+	}
+}
+
+// Subscribe starts (or joins) a subscription for dataref/dataType and
+// returns a channel of samples delivered at up to hz times per second, plus
+// a CancelFunc that removes this subscriber. The upstream subscription
+// keeps running, at the fastest rate any remaining subscriber requested,
+// until the last subscriber cancels.
+func (registry *Registry) Subscribe(dataref, dataType string, hz int) (<-chan Sample, CancelFunc) {
+	key := subscriptionKey{dataref: dataref, dataType: dataType}
+
+	registry.mu.Lock()
+	p, exists := registry.polls[key]
+	if !exists {
+		p = &poll{
+			subscribers: make(map[int]*subscriber),
+			stop:        make(chan struct{}),
+		}
+		registry.polls[key] = p
+	}
+
+	id := p.nextID
+	p.nextID++
+	sub := &subscriber{ch: make(chan Sample, 1), rateHz: hz}
+	p.subscribers[id] = sub
+	rate := registry.rateHzLocked(p)
+	registry.mu.Unlock()
+
+	registry.syncUpstream(key, p, rate, !exists)
+
+	var canceled bool
+	cancel := func() {
+		registry.mu.Lock()
+		if canceled {
+			registry.mu.Unlock()
+			return
+		}
+		canceled = true
+
+		delete(p.subscribers, id)
+		close(sub.ch)
+		last := len(p.subscribers) == 0
+		if last {
+			delete(registry.polls, key)
+		}
+		rate := registry.rateHzLocked(p)
+		registry.mu.Unlock()
+
+		if last {
+			registry.teardownUpstream(key, p)
+		} else {
+			registry.syncUpstream(key, p, rate, false)
+		}
+	}
+
+	return sub.ch, cancel
+}
+
+// rateHzLocked returns the fastest rate requested by any current subscriber
+// of p, or 0 if p has no subscribers left. Callers must hold registry.mu.
+func (registry *Registry) rateHzLocked(p *poll) int {
+	rate := 0
+	for _, sub := range p.subscribers {
+		if sub.rateHz > rate {
+			rate = sub.rateHz
+		}
+	}
+	return rate
+}
+
+// syncUpstream tells X-Plane about key's current effective rate, either by
+// (re)sending a push subscription request over the shared dedicated
+// connection, or, if the transport doesn't support one, by starting the
+// per-key fallback ticker loop the first time this key is subscribed.
+func (registry *Registry) syncUpstream(key subscriptionKey, p *poll, rateHz int, firstSubscriber bool) {
+	if conn, ok := registry.dedicatedConn(); ok {
+		data := fmt.Sprintf("dataref|subscribe|%s|%s|%d", key.dataType, key.dataref, rateHz)
+		if err := conn.Send([]byte(data)); err != nil {
+			color.Red("Subscription request for %s failed: %v\n", key.dataref, err)
+		}
+		return
+	}
+
+	if firstSubscriber {
+		go registry.pollFallback(key, p)
+	}
+}
+
+// teardownUpstream tells X-Plane that nothing is subscribed to key anymore,
+// either by sending an unsubscribe request over the dedicated connection or,
+// for the fallback loop, by stopping its ticker.
+func (registry *Registry) teardownUpstream(key subscriptionKey, p *poll) {
+	if conn, ok := registry.dedicatedConn(); ok {
+		data := fmt.Sprintf("dataref|unsubscribe|%s", key.dataref)
+		if err := conn.Send([]byte(data)); err != nil {
+			color.Red("Unsubscribe request for %s failed: %v\n", key.dataref, err)
+		}
+		return
+	}
+
+	close(p.stop)
+}
+
+// dedicatedConn lazily dials the dedicated push-subscription connection and
+// starts its recv loop, if the registry's Transport supports Dialer and the
+// dialed connection supports RawReceiver. It returns ok=false if either
+// interface is unsupported, in which case callers fall back to polling.
+func (registry *Registry) dedicatedConn() (Transport, bool) {
+	registry.subConnOnce.Do(func() {
+		dialer, ok := registry.reader.client.(Dialer)
+		if !ok {
+			return
+		}
+
+		conn, err := dialer.Dial()
+		if err != nil {
+			color.Red("Failed to open dedicated subscription connection: %v\n", err)
+			return
+		}
+
+		receiver, ok := conn.(RawReceiver)
+		if !ok {
+			_ = conn.Close()
+			return
+		}
+
+		registry.subConn = conn
+		go registry.recvLoop(receiver)
+	})
+
+	return registry.subConn, registry.subConn != nil
+}
+
+// recvLoop continuously reads inbound "dataref|update|{type}|{name}|{value}"
+// packets off the dedicated connection and fans each one out to the
+// subscribers of the matching key, until the connection errors (e.g. it was
+// closed). This is the one goroutine that replaces a per-tick round trip
+// per subscriber with a single, continuously-read push stream.
+func (registry *Registry) recvLoop(receiver RawReceiver) {
+	for {
+		raw, err := receiver.RecvRaw()
+		if err != nil {
+			return
+		}
+
+		key, sample, ok := decodeUpdate(string(raw))
+		if !ok {
+			color.Yellow("Discarding malformed subscription update: %q\n", raw)
+			continue
+		}
+
+		registry.mu.Lock()
+		p, exists := registry.polls[key]
+		if exists {
+			for _, sub := range p.subscribers {
+				select {
+				case sub.ch <- sample:
+				default:
+					// Slow subscriber: drop the sample rather than block the recv loop.
+				}
+			}
+		}
+		registry.mu.Unlock()
+	}
+}
+
+// decodeUpdate parses a "dataref|update|{type}|{name}|{value}" packet into
+// the subscriptionKey it answers and the Sample it carries.
+func decodeUpdate(body string) (subscriptionKey, Sample, bool) {
+	parts := strings.SplitN(body, "|", 5)
+	if len(parts) != 5 || parts[0] != "dataref" || parts[1] != "update" {
+		return subscriptionKey{}, Sample{}, false
+	}
+
+	dataType, dataref, value := parts[2], parts[3], parts[4]
+	key := subscriptionKey{dataref: dataref, dataType: dataType}
+	sample := Sample{Dataref: dataref, Value: value, At: time.Now()}
+	return key, sample, true
+}
+
+// pollFallback drives key by calling DataRefReader.Read on a ticker, for
+// transports that don't support a dedicated push-subscription connection.
+// It still coalesces every local subscriber of key onto one goroutine, but,
+// unlike recvLoop, it pays one round trip to X-Plane per tick regardless of
+// how many subscribers share it.
+func (registry *Registry) pollFallback(key subscriptionKey, p *poll) {
+	for {
+		registry.mu.Lock()
+		rateHz := registry.rateHzLocked(p)
+		registry.mu.Unlock()
+		if rateHz <= 0 {
+			return
+		}
+
+		timer := time.NewTimer(time.Second / time.Duration(rateHz))
+		select {
+		case <-p.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		value := registry.reader.Read(key.dataref, key.dataType)
+		sample := Sample{Dataref: key.dataref, Value: value, At: time.Now()}
+
+		registry.mu.Lock()
+		for _, sub := range p.subscribers {
+			select {
+			case sub.ch <- sample:
+			default:
+				// Slow subscriber: drop the sample rather than block the poll loop.
+			}
+		}
+		registry.mu.Unlock()
+	}
+}
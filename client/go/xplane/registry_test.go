@@ -0,0 +1,184 @@
+package xplane
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeReadTransport is a Transport stub that answers every SendAndRecv with
+// an incrementing counter instead of talking to X-Plane, so pollFallback's
+// one-read-per-tick behavior can be observed deterministically. It does not
+// implement Dialer or RawReceiver, so Registry always falls back to
+// pollFallback against it rather than opening a dedicated push connection.
+type fakeReadTransport struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (f *fakeReadTransport) SendAndRecv(data []byte) []byte {
+	f.mu.Lock()
+	f.calls++
+	n := f.calls
+	f.mu.Unlock()
+	return []byte(fmt.Sprintf("dataref|read|%d", n))
+}
+
+func (f *fakeReadTransport) SendAndRecvErr(data []byte) ([]byte, error) {
+	return f.SendAndRecv(data), nil
+}
+
+func (f *fakeReadTransport) Send(data []byte) error { return nil }
+
+func (f *fakeReadTransport) Close() error { return nil }
+
+func (f *fakeReadTransport) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+// recvSample waits for a single sample on ch, failing the test if none
+// arrives in time.
+func recvSample(t *testing.T, ch <-chan Sample) Sample {
+	t.Helper()
+	select {
+	case sample := <-ch:
+		return sample
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a sample")
+		return Sample{}
+	}
+}
+
+// TestRegistrySubscribeFanOut confirms that two subscribers of the same
+// dataref/type share a single upstream poll: both receive the value read on
+// the same tick, rather than each triggering their own read.
+func TestRegistrySubscribeFanOut(t *testing.T) {
+	transport := &fakeReadTransport{}
+	registry := NewRegistry(NewDataRefReader(transport))
+
+	ch1, cancel1 := registry.Subscribe("sim/test/dataref", "float", 5)
+	defer cancel1()
+	ch2, cancel2 := registry.Subscribe("sim/test/dataref", "float", 5)
+	defer cancel2()
+
+	s1 := recvSample(t, ch1)
+	s2 := recvSample(t, ch2)
+
+	if s1.Value != s2.Value {
+		t.Errorf("fan-out mismatch: ch1 = %q, ch2 = %q, want the same value from the same tick", s1.Value, s2.Value)
+	}
+}
+
+// TestRegistryRateUpgradeDowngrade confirms the effective poll rate for a
+// key tracks the fastest rate any current subscriber requested, and drops
+// back down once that subscriber cancels.
+func TestRegistryRateUpgradeDowngrade(t *testing.T) {
+	transport := &fakeReadTransport{}
+	registry := NewRegistry(NewDataRefReader(transport))
+	key := subscriptionKey{dataref: "sim/test/dataref", dataType: "float"}
+
+	_, cancelSlow := registry.Subscribe("sim/test/dataref", "float", 1)
+	defer cancelSlow()
+
+	registry.mu.Lock()
+	p := registry.polls[key]
+	rate := registry.rateHzLocked(p)
+	registry.mu.Unlock()
+	if rate != 1 {
+		t.Fatalf("rate after first subscriber = %d, want 1", rate)
+	}
+
+	_, cancelFast := registry.Subscribe("sim/test/dataref", "float", 50)
+
+	registry.mu.Lock()
+	rate = registry.rateHzLocked(p)
+	registry.mu.Unlock()
+	if rate != 50 {
+		t.Fatalf("rate after upgrade = %d, want 50", rate)
+	}
+
+	cancelFast()
+
+	registry.mu.Lock()
+	rate = registry.rateHzLocked(p)
+	registry.mu.Unlock()
+	if rate != 1 {
+		t.Fatalf("rate after downgrade = %d, want 1", rate)
+	}
+}
+
+// TestRegistryRefcounting confirms canceling one subscriber of a shared key
+// leaves the others unaffected: their channel keeps delivering samples and
+// the upstream poll keeps running.
+func TestRegistryRefcounting(t *testing.T) {
+	transport := &fakeReadTransport{}
+	registry := NewRegistry(NewDataRefReader(transport))
+
+	ch1, cancel1 := registry.Subscribe("sim/test/dataref", "float", 20)
+	ch2, cancel2 := registry.Subscribe("sim/test/dataref", "float", 20)
+	defer cancel2()
+
+	cancel1()
+
+	select {
+	case _, ok := <-ch1:
+		if ok {
+			t.Error("expected ch1 to be closed after cancel1")
+		}
+	case <-time.After(time.Second):
+		t.Error("timed out waiting for ch1 to close")
+	}
+
+	sample := recvSample(t, ch2)
+	if sample.Dataref != "sim/test/dataref" {
+		t.Errorf("unexpected dataref %q on ch2 after cancel1", sample.Dataref)
+	}
+}
+
+// TestRegistryTeardownOnLastCancel confirms that canceling the last
+// subscriber of a key both removes it from Registry's bookkeeping and stops
+// the fallback poll loop, instead of leaking a goroutine that keeps reading.
+func TestRegistryTeardownOnLastCancel(t *testing.T) {
+	transport := &fakeReadTransport{}
+	registry := NewRegistry(NewDataRefReader(transport))
+	key := subscriptionKey{dataref: "sim/test/dataref", dataType: "float"}
+
+	_, cancel := registry.Subscribe("sim/test/dataref", "float", 50)
+
+	registry.mu.Lock()
+	_, exists := registry.polls[key]
+	registry.mu.Unlock()
+	if !exists {
+		t.Fatal("expected poll to be registered while subscribed")
+	}
+
+	cancel()
+
+	registry.mu.Lock()
+	_, exists = registry.polls[key]
+	registry.mu.Unlock()
+	if exists {
+		t.Fatal("expected poll to be removed after last cancel")
+	}
+
+	callsAtTeardown := transport.callCount()
+	time.Sleep(100 * time.Millisecond)
+	if after := transport.callCount(); after != callsAtTeardown {
+		t.Errorf("expected no further reads after teardown, calls went from %d to %d", callsAtTeardown, after)
+	}
+}
+
+// TestRegistrySubscribeParses confirms decodeUpdate, used by recvLoop for
+// transports that support a dedicated push connection, correctly rejects
+// malformed packets without panicking.
+func TestRegistryDecodeUpdateRejectsMalformed(t *testing.T) {
+	for _, body := range []string{"", "garbage", "dataref|read|float|name|1", strings.Repeat("|", 10)} {
+		if _, _, ok := decodeUpdate(body); ok {
+			t.Errorf("decodeUpdate(%q) = ok, want rejected", body)
+		}
+	}
+}
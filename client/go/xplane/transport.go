@@ -0,0 +1,41 @@
+package xplane
+
+// Transport abstracts how a client exchanges request/response frames with
+// X-Plane, so DataRefReader does not need to know whether it is talking
+// over UDP or a local Unix domain socket. UdpClient and UnixClient both
+// implement it.
+type Transport interface {
+	// SendAndRecv sends data and waits for a single response, returning
+	// nil if the request could not be sent or no response arrived before
+	// the transport's configured timeout.
+	SendAndRecv(data []byte) []byte
+
+	// SendAndRecvErr behaves like SendAndRecv but also returns the
+	// underlying error, so callers that need to distinguish a timeout
+	// from a transport failure (rather than just getting back nil) can.
+	SendAndRecvErr(data []byte) ([]byte, error)
+
+	// Send writes data without waiting for a response, for requests such
+	// as commands that X-Plane does not acknowledge.
+	Send(data []byte) error
+
+	// Close releases the underlying connection. It is safe to call once
+	// a Transport is no longer needed.
+	Close() error
+}
+
+// Dialer is implemented by transports that can open a second, independent
+// connection to the same endpoint. Registry uses this to open a connection
+// dedicated to push subscriptions, separate from the one callers use for
+// ordinary request/response traffic.
+type Dialer interface {
+	Dial() (Transport, error)
+}
+
+// RawReceiver is implemented by transports that can block waiting for the
+// next inbound packet without first sending a request. Registry uses this,
+// together with Dialer, to read a continuous stream of subscription
+// updates instead of polling with SendAndRecv.
+type RawReceiver interface {
+	RecvRaw() ([]byte, error)
+}
@@ -0,0 +1,167 @@
+package xplane
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Both UdpClient and UnixClient are expected to behave identically from a
+// caller's point of view: echo a request back through SendAndRecv/
+// SendAndRecvErr, let Send fire without waiting for a reply, and surface a
+// timeout rather than blocking forever when nothing answers. These tests
+// run the same assertions against both, backed by loopback echo servers.
+
+// udpEchoServer starts a UDP listener on 127.0.0.1 that echoes every
+// datagram it receives back to its sender, and returns the address to dial
+// and a stop function.
+func udpEchoServer(t *testing.T) (addr *net.UDPAddr, stop func()) {
+	t.Helper()
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 0})
+	if err != nil {
+		t.Fatalf("failed to start UDP echo server: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		buffer := make([]byte, 2048)
+		for {
+			size, from, err := conn.ReadFromUDP(buffer)
+			if err != nil {
+				close(done)
+				return
+			}
+			if _, err := conn.WriteToUDP(buffer[:size], from); err != nil {
+				close(done)
+				return
+			}
+		}
+	}()
+
+	return conn.LocalAddr().(*net.UDPAddr), func() {
+		_ = conn.Close()
+		<-done
+	}
+}
+
+// unixEchoServer starts a unixgram listener under dir that echoes every
+// datagram it receives back to its sender, and returns the socket path to
+// dial and a stop function.
+func unixEchoServer(t *testing.T, dir string) (path string, stop func()) {
+	t.Helper()
+
+	path = filepath.Join(dir, "echo.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("failed to start Unix datagram echo server: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		buffer := make([]byte, 2048)
+		for {
+			size, from, err := conn.ReadFromUnix(buffer)
+			if err != nil {
+				close(done)
+				return
+			}
+			if _, err := conn.WriteToUnix(buffer[:size], from); err != nil {
+				close(done)
+				return
+			}
+		}
+	}()
+
+	return path, func() {
+		_ = conn.Close()
+		<-done
+	}
+}
+
+func TestUdpClientConformance(t *testing.T) {
+	addr, stop := udpEchoServer(t)
+	defer stop()
+
+	client := NewUdpClient(addr.IP.String(), addr.Port, 1)
+	if client == nil {
+		t.Fatal("NewUdpClient returned nil")
+	}
+	defer client.Close()
+
+	testTransportConformance(t, client)
+}
+
+func TestUnixClientConformance(t *testing.T) {
+	dir := t.TempDir()
+	remotePath, stop := unixEchoServer(t, dir)
+	defer stop()
+
+	localPath := filepath.Join(dir, "client.sock")
+	client := NewUnixClient(localPath, remotePath, 1)
+	if client == nil {
+		t.Fatal("NewUnixClient returned nil")
+	}
+	defer client.Close()
+
+	testTransportConformance(t, client)
+	if _, err := os.Stat(localPath); err != nil {
+		t.Fatalf("expected local socket %s to exist: %v", localPath, err)
+	}
+}
+
+// testTransportConformance runs the same assertions against any Transport
+// backed by a live echo server, so UdpClient and UnixClient are held to the
+// same contract.
+func testTransportConformance(t *testing.T, transport Transport) {
+	t.Helper()
+
+	request := []byte("dataref|read|float|sim/test/dataref")
+	response := transport.SendAndRecv(request)
+	if !bytes.Equal(response, request) {
+		t.Errorf("SendAndRecv = %q, want echoed %q", response, request)
+	}
+
+	response, err := transport.SendAndRecvErr(request)
+	if err != nil {
+		t.Errorf("SendAndRecvErr returned error: %v", err)
+	}
+	if !bytes.Equal(response, request) {
+		t.Errorf("SendAndRecvErr = %q, want echoed %q", response, request)
+	}
+
+	if err := transport.Send(request); err != nil {
+		t.Errorf("Send returned error: %v", err)
+	}
+}
+
+// TestUdpClientSendAndRecvTimeout confirms SendAndRecv returns nil rather
+// than blocking when nothing answers, since DataRefReader relies on this to
+// report a failed read instead of hanging.
+func TestUdpClientSendAndRecvTimeout(t *testing.T) {
+	// Bind a UDP socket so the port is reserved but nothing reads from it.
+	silent, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 0})
+	if err != nil {
+		t.Fatalf("failed to reserve a silent UDP port: %v", err)
+	}
+	addr := silent.LocalAddr().(*net.UDPAddr)
+	defer silent.Close()
+
+	client := NewUdpClient(addr.IP.String(), addr.Port, 1)
+	if client == nil {
+		t.Fatal("NewUdpClient returned nil")
+	}
+	defer client.Close()
+
+	start := time.Now()
+	response := client.SendAndRecv([]byte("dataref|read|float|sim/test/dataref"))
+	if response != nil {
+		t.Errorf("SendAndRecv = %q, want nil on timeout", response)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("SendAndRecv took %v, want it to respect the 1s client timeout (with one retry)", elapsed)
+	}
+}
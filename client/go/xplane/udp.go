@@ -0,0 +1,228 @@
+// Package xplane provides a reusable client for communicating with X-Plane
+// over its UDP dataref protocol. It is shared by the demonstration CLI
+// (client/go/main), the benchmark subsystem, and the gRPC bridge daemon
+// (client/go/bridge), so all three talk to X-Plane through the same
+// connection, timeout, and error-handling semantics.
+package xplane
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// UdpClient represents a UDP client for communicating with X-Plane.
+// It encapsulates the connection details, server address, and timeout configuration
+// for sending requests and receiving responses. It implements Transport.
+type UdpClient struct {
+	serverAddr *net.UDPAddr  // The UDP address of the X-Plane server
+	connection *net.UDPConn  // The UDP connection for communication
+	timeout    time.Duration // Timeout duration for read operations
+
+	// mu serializes every exchange on connection. A datagram socket has no
+	// way to tell which caller a given inbound packet answers, so two
+	// concurrent SendAndRecv calls could otherwise read each other's
+	// response; holding mu for the full send-then-receive cycle (and
+	// across a reconnect) keeps callers from crossing wires.
+	mu sync.Mutex
+}
+
+// NewUdpClient creates a new UDP client for communicating with X-Plane.
+// It establishes a UDP connection to the specified server with the given timeout.
+//
+// Parameters:
+//   - host: The IP address or hostname of the X-Plane server.
+//   - port: The port number on which X-Plane is listening for UDP connections.
+//   - timeoutSecs: The timeout in seconds for read operations.
+//
+// Returns:
+//   - *UdpClient: A pointer to the newly created UDP client.
+//   - nil: If the connection could not be established.
+//
+// Example:
+//
+//	client := xplane.NewUdpClient("127.0.0.1", 49000, 5)
+//	if client != nil {
+//	    // Use client for communication
+//	}
+func NewUdpClient(host string, port, timeoutSecs int) *UdpClient {
+	fmt.Println(strings.Repeat("=", 100))
+	color.Cyan("Creating UDP client to server %s:%d with timeout %d seconds\n", host, port, timeoutSecs)
+
+	serverAddr := &net.UDPAddr{
+		IP:   net.ParseIP(host),
+		Port: port,
+	}
+	timeout := time.Duration(timeoutSecs) * time.Second
+
+	connection, err := net.DialUDP("udp", nil, serverAddr)
+	if err != nil {
+		color.Red("UDP error while creating client: %v\n", err)
+		return nil
+	}
+
+	color.Green("Created UDP client successfully\n")
+
+	return &UdpClient{
+		serverAddr: serverAddr,
+		connection: connection,
+		timeout:    timeout,
+	}
+}
+
+// SendAndRecv sends data to the X-Plane server and waits for a response.
+// It handles the complete request-response cycle with proper timeout handling.
+//
+// The method:
+// 1. Sends the provided data to the connected server
+// 2. Sets a read deadline based on the client's timeout configuration
+// 3. Waits for a response up to the specified timeout
+// 4. Returns the received data or nil if an error occurs
+//
+// If the underlying connection has gone bad (X-Plane restarted, network
+// interface bounced, ...), SendAndRecv redials once and retries the
+// exchange before giving up, so callers do not need their own reconnect
+// logic for transient failures.
+//
+// Parameters:
+//   - data: The byte slice containing the data to send to X-Plane.
+//
+// Returns:
+//   - []byte: The response received from X-Plane.
+//   - nil: If an error occurs during sending or receiving, including after a retry.
+//
+// Note:
+//
+//	The method uses a 2048-byte buffer for receiving data, which is
+//	sufficient for typical X-Plane data reference responses.
+func (client *UdpClient) SendAndRecv(data []byte) []byte {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	response, err := client.sendAndRecv(data)
+	if err == nil {
+		return response
+	}
+
+	color.Yellow("UDP connection appears stale, reconnecting: %v\n", err)
+	if reconnectErr := client.reconnect(); reconnectErr != nil {
+		color.Red("UDP error while reconnecting: %v\n", reconnectErr)
+		return nil
+	}
+
+	response, err = client.sendAndRecv(data)
+	if err != nil {
+		color.Red("UDP error after reconnect: %v\n", err)
+		return nil
+	}
+	return response
+}
+
+// sendAndRecv performs a single send/receive cycle over the current
+// connection without any reconnect attempt.
+func (client *UdpClient) sendAndRecv(data []byte) ([]byte, error) {
+	_, err := client.connection.Write(data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.connection.SetReadDeadline(time.Now().Add(client.timeout))
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := make([]byte, 2048)
+
+	size, _, err := client.connection.ReadFromUDP(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer[:size], nil
+}
+
+// SendAndRecvErr behaves like SendAndRecv but returns the underlying error
+// instead of discarding it, so callers (such as the dataref write path)
+// can distinguish a timeout from a transport failure.
+func (client *UdpClient) SendAndRecvErr(data []byte) ([]byte, error) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	response, err := client.sendAndRecv(data)
+	if err == nil {
+		return response, nil
+	}
+
+	if reconnectErr := client.reconnect(); reconnectErr != nil {
+		return nil, reconnectErr
+	}
+
+	return client.sendAndRecv(data)
+}
+
+// Send writes data to the server without waiting for a response, for
+// requests such as commands that X-Plane does not acknowledge.
+func (client *UdpClient) Send(data []byte) error {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	_, err := client.connection.Write(data)
+	return err
+}
+
+// Dial opens a second, independent UDP connection to the same server
+// address, for a caller (such as Registry) that wants a connection
+// dedicated to its own traffic rather than sharing this one.
+func (client *UdpClient) Dial() (Transport, error) {
+	connection, err := net.DialUDP("udp", nil, client.serverAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UdpClient{
+		serverAddr: client.serverAddr,
+		connection: connection,
+		timeout:    client.timeout,
+	}, nil
+}
+
+// RecvRaw blocks until the next datagram arrives on this connection and
+// returns it, with no read deadline and without sending anything first. It
+// is meant for a connection dedicated to receiving an upstream push
+// subscription, not one shared with SendAndRecv callers.
+func (client *UdpClient) RecvRaw() ([]byte, error) {
+	buffer := make([]byte, 2048)
+	size, _, err := client.connection.ReadFromUDP(buffer)
+	if err != nil {
+		return nil, err
+	}
+	return buffer[:size], nil
+}
+
+// reconnect closes the current connection, if any, and dials a fresh one
+// to the same server address.
+func (client *UdpClient) reconnect() error {
+	if client.connection != nil {
+		_ = client.connection.Close()
+	}
+
+	connection, err := net.DialUDP("udp", nil, client.serverAddr)
+	if err != nil {
+		return err
+	}
+
+	client.connection = connection
+	return nil
+}
+
+// Close releases the underlying UDP connection.
+func (client *UdpClient) Close() error {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	return client.connection.Close()
+}
@@ -0,0 +1,201 @@
+package xplane
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// UnixClient communicates with X-Plane (or a local relay/proxy standing in
+// for it) over a Unix domain datagram socket instead of UDP. It implements
+// Transport and offers the same timeout and reconnect semantics as
+// UdpClient, which makes it useful for containerized deployments where
+// X-Plane and its consumers share a volume and want to avoid UDP checksum
+// and loopback overhead.
+//
+// Unlike a connected UDP socket, "unixgram" requires the caller to bind its
+// own local socket path so replies have somewhere to arrive; that path is
+// owned and cleaned up by the caller, not by X-Plane.
+type UnixClient struct {
+	localAddr  *net.UnixAddr
+	remoteAddr *net.UnixAddr
+	connection *net.UnixConn
+	timeout    time.Duration
+
+	// mu serializes every exchange on connection, for the same reason
+	// UdpClient.mu does: a datagram socket can't tell which caller a given
+	// inbound packet answers, so concurrent callers without this lock
+	// could read each other's response.
+	mu sync.Mutex
+}
+
+// NewUnixClient creates a Unix domain datagram client bound to
+// localSocketPath and connected to remoteSocketPath, which X-Plane (or the
+// relay in front of it) is expected to be listening on.
+//
+// Parameters:
+//   - localSocketPath: Filesystem path this client binds to receive replies on.
+//     The caller owns this path and is responsible for removing it on shutdown.
+//   - remoteSocketPath: Filesystem path of the remote unixgram socket to send requests to.
+//   - timeoutSecs: The timeout in seconds for read operations.
+//
+// Returns:
+//   - *UnixClient: A pointer to the newly created Unix domain client.
+//   - nil: If the local socket could not be bound or the remote address could not be resolved.
+func NewUnixClient(localSocketPath, remoteSocketPath string, timeoutSecs int) *UnixClient {
+	color.Cyan("Creating Unix datagram client %s -> %s with timeout %d seconds\n", localSocketPath, remoteSocketPath, timeoutSecs)
+
+	localAddr := &net.UnixAddr{Name: localSocketPath, Net: "unixgram"}
+	remoteAddr := &net.UnixAddr{Name: remoteSocketPath, Net: "unixgram"}
+
+	connection, err := net.DialUnix("unixgram", localAddr, remoteAddr)
+	if err != nil {
+		color.Red("Unix datagram error while creating client: %v\n", err)
+		return nil
+	}
+
+	color.Green("Created Unix datagram client successfully\n")
+
+	return &UnixClient{
+		localAddr:  localAddr,
+		remoteAddr: remoteAddr,
+		connection: connection,
+		timeout:    time.Duration(timeoutSecs) * time.Second,
+	}
+}
+
+// SendAndRecv sends data over the Unix datagram socket and waits for a
+// response, redialing once and retrying if the connection has gone bad.
+// See UdpClient.SendAndRecv, which this mirrors.
+func (client *UnixClient) SendAndRecv(data []byte) []byte {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	response, err := client.sendAndRecv(data)
+	if err == nil {
+		return response
+	}
+
+	color.Yellow("Unix datagram connection appears stale, reconnecting: %v\n", err)
+	if reconnectErr := client.reconnect(); reconnectErr != nil {
+		color.Red("Unix datagram error while reconnecting: %v\n", reconnectErr)
+		return nil
+	}
+
+	response, err = client.sendAndRecv(data)
+	if err != nil {
+		color.Red("Unix datagram error after reconnect: %v\n", err)
+		return nil
+	}
+	return response
+}
+
+func (client *UnixClient) sendAndRecv(data []byte) ([]byte, error) {
+	_, err := client.connection.Write(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.connection.SetReadDeadline(time.Now().Add(client.timeout)); err != nil {
+		return nil, err
+	}
+
+	buffer := make([]byte, 2048)
+
+	size, err := client.connection.Read(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer[:size], nil
+}
+
+// SendAndRecvErr behaves like SendAndRecv but returns the underlying error
+// instead of discarding it, so callers (such as the dataref write path)
+// can distinguish a timeout from a transport failure.
+func (client *UnixClient) SendAndRecvErr(data []byte) ([]byte, error) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	response, err := client.sendAndRecv(data)
+	if err == nil {
+		return response, nil
+	}
+
+	if reconnectErr := client.reconnect(); reconnectErr != nil {
+		return nil, reconnectErr
+	}
+
+	return client.sendAndRecv(data)
+}
+
+// Send writes data to the server without waiting for a response, for
+// requests such as commands that X-Plane does not acknowledge.
+func (client *UnixClient) Send(data []byte) error {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	_, err := client.connection.Write(data)
+	return err
+}
+
+// Dial opens a second, independent Unix datagram connection to the same
+// remote socket, bound to its own local socket path so its replies don't
+// collide with this connection's. It is for a caller (such as Registry)
+// that wants a connection dedicated to its own traffic.
+func (client *UnixClient) Dial() (Transport, error) {
+	localAddr := &net.UnixAddr{Name: client.localAddr.Name + ".dedicated", Net: "unixgram"}
+
+	connection, err := net.DialUnix("unixgram", localAddr, client.remoteAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnixClient{
+		localAddr:  localAddr,
+		remoteAddr: client.remoteAddr,
+		connection: connection,
+		timeout:    client.timeout,
+	}, nil
+}
+
+// RecvRaw blocks until the next datagram arrives on this connection and
+// returns it, with no read deadline and without sending anything first. It
+// is meant for a connection dedicated to receiving an upstream push
+// subscription, not one shared with SendAndRecv callers.
+func (client *UnixClient) RecvRaw() ([]byte, error) {
+	buffer := make([]byte, 2048)
+	size, err := client.connection.Read(buffer)
+	if err != nil {
+		return nil, err
+	}
+	return buffer[:size], nil
+}
+
+// reconnect closes the current connection and rebinds/redials the same
+// local and remote addresses.
+func (client *UnixClient) reconnect() error {
+	if client.connection != nil {
+		_ = client.connection.Close()
+	}
+
+	connection, err := net.DialUnix("unixgram", client.localAddr, client.remoteAddr)
+	if err != nil {
+		return err
+	}
+
+	client.connection = connection
+	return nil
+}
+
+// Close releases the underlying Unix domain socket connection. It does not
+// remove the local socket file; callers that bound a filesystem path (as
+// opposed to using the abstract namespace) are responsible for that.
+func (client *UnixClient) Close() error {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	return client.connection.Close()
+}
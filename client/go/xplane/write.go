@@ -0,0 +1,77 @@
+package xplane
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// writeAck is the acknowledgement X-Plane's dataref write request expects.
+const writeAck = "dataref|write|ack"
+
+// WriteFloat writes v to the float dataref name.
+//
+// It rejects NaN and Inf locally, since X-Plane has no meaningful way to
+// represent them, and otherwise returns a *RequestError distinguishing a
+// timeout, a transport failure, and a malformed acknowledgement, rather
+// than the nil/"" every failure used to collapse into.
+func (reader *DataRefReader) WriteFloat(name string, v float32) error {
+	if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+		return &RequestError{Kind: ErrKindValidation, Op: "WriteFloat", Target: name, Err: fmt.Errorf("value %v is not finite", v)}
+	}
+	return reader.write("WriteFloat", name, "float", strconv.FormatFloat(float64(v), 'f', -1, 32))
+}
+
+// WriteInt writes v to the int dataref name.
+func (reader *DataRefReader) WriteInt(name string, v int) error {
+	return reader.write("WriteInt", name, "int", strconv.Itoa(v))
+}
+
+// WriteArray writes v to element index of the array dataref name, which
+// has the given length.
+//
+// X-Plane silently ignores writes to an out-of-range array index, so this
+// validates locally instead of letting a typo disappear without a trace.
+func (reader *DataRefReader) WriteArray(name string, index, length int, v float32) error {
+	if index < 0 || index >= length {
+		return &RequestError{Kind: ErrKindValidation, Op: "WriteArray", Target: name, Err: fmt.Errorf("index %d out of bounds for length %d", index, length)}
+	}
+	if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+		return &RequestError{Kind: ErrKindValidation, Op: "WriteArray", Target: name, Err: fmt.Errorf("value %v is not finite", v)}
+	}
+
+	indexedName := fmt.Sprintf("%s[%d]", name, index)
+	return reader.write("WriteArray", indexedName, "float", strconv.FormatFloat(float64(v), 'f', -1, 32))
+}
+
+// write formats and sends a dataref write request and validates the
+// acknowledgement, following the X-Plane UDP protocol format
+// "dataref|write|{type}|{dataref}|{value}".
+func (reader *DataRefReader) write(op, name, dataType, value string) error {
+	data := fmt.Sprintf("dataref|write|%s|%s|%s", dataType, name, value)
+
+	fmt.Println(strings.Repeat("=", 100))
+	color.Cyan("Sending dataref write request: %s\n", data)
+
+	response, err := reader.client.SendAndRecvErr([]byte(data))
+	if err != nil {
+		color.Red("Dataref %s write failed: %v\n", name, err)
+		return &RequestError{Kind: classifyTransportErr(err), Op: op, Target: name, Err: err}
+	}
+	if response == nil {
+		color.Red("Dataref %s write failed: no response from server\n", name)
+		return &RequestError{Kind: ErrKindTransport, Op: op, Target: name, Err: errors.New("no response from server")}
+	}
+
+	body := string(response)
+	color.Yellow("Received dataref write response body: %s\n", body)
+	if !strings.HasPrefix(body, writeAck) {
+		return &RequestError{Kind: ErrKindMalformedResponse, Op: op, Target: name, Err: fmt.Errorf("unexpected response %q", body)}
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package xplane
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// recordingServer is a UDP listener that captures the first datagram it
+// receives on recv and, if ack is non-nil, replies with it. It is used to
+// assert the exact wire format WriteFloat/WriteInt/WriteArray/
+// CommandSender.Send put on the wire, byte-for-byte.
+func recordingServer(t *testing.T, ack []byte) (addr *net.UDPAddr, recv <-chan []byte, stop func()) {
+	t.Helper()
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 0})
+	if err != nil {
+		t.Fatalf("failed to start recording server: %v", err)
+	}
+
+	received := make(chan []byte, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buffer := make([]byte, 2048)
+		size, from, err := conn.ReadFromUDP(buffer)
+		if err != nil {
+			return
+		}
+		body := make([]byte, size)
+		copy(body, buffer[:size])
+		received <- body
+
+		if ack != nil {
+			_, _ = conn.WriteToUDP(ack, from)
+		}
+	}()
+
+	return conn.LocalAddr().(*net.UDPAddr), received, func() {
+		_ = conn.Close()
+		<-done
+	}
+}
+
+func TestWriteFloatWireFormat(t *testing.T) {
+	addr, received, stop := recordingServer(t, []byte(writeAck))
+	defer stop()
+
+	client := NewUdpClient(addr.IP.String(), addr.Port, 1)
+	if client == nil {
+		t.Fatal("NewUdpClient returned nil")
+	}
+	defer client.Close()
+
+	reader := NewDataRefReader(client)
+	if err := reader.WriteFloat("sim/test/dataref", 1.5); err != nil {
+		t.Fatalf("WriteFloat returned error: %v", err)
+	}
+
+	assertReceived(t, received, "dataref|write|float|sim/test/dataref|1.5")
+}
+
+func TestWriteIntWireFormat(t *testing.T) {
+	addr, received, stop := recordingServer(t, []byte(writeAck))
+	defer stop()
+
+	client := NewUdpClient(addr.IP.String(), addr.Port, 1)
+	if client == nil {
+		t.Fatal("NewUdpClient returned nil")
+	}
+	defer client.Close()
+
+	reader := NewDataRefReader(client)
+	if err := reader.WriteInt("sim/test/count", 42); err != nil {
+		t.Fatalf("WriteInt returned error: %v", err)
+	}
+
+	assertReceived(t, received, "dataref|write|int|sim/test/count|42")
+}
+
+func TestWriteArrayWireFormat(t *testing.T) {
+	addr, received, stop := recordingServer(t, []byte(writeAck))
+	defer stop()
+
+	client := NewUdpClient(addr.IP.String(), addr.Port, 1)
+	if client == nil {
+		t.Fatal("NewUdpClient returned nil")
+	}
+	defer client.Close()
+
+	reader := NewDataRefReader(client)
+	if err := reader.WriteArray("sim/test/engines", 2, 8, 0.75); err != nil {
+		t.Fatalf("WriteArray returned error: %v", err)
+	}
+
+	assertReceived(t, received, "dataref|write|float|sim/test/engines[2]|0.75")
+}
+
+func TestWriteArrayRejectsOutOfBoundsIndex(t *testing.T) {
+	addr, _, stop := recordingServer(t, nil)
+	defer stop()
+
+	client := NewUdpClient(addr.IP.String(), addr.Port, 1)
+	if client == nil {
+		t.Fatal("NewUdpClient returned nil")
+	}
+	defer client.Close()
+
+	reader := NewDataRefReader(client)
+	err := reader.WriteArray("sim/test/engines", 8, 8, 0.5)
+	if err == nil {
+		t.Fatal("expected an error for an out-of-bounds index, got nil")
+	}
+
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || reqErr.Kind != ErrKindValidation {
+		t.Fatalf("expected a validation RequestError, got %v", err)
+	}
+}
+
+func TestCommandSenderWireFormat(t *testing.T) {
+	addr, received, stop := recordingServer(t, nil)
+	defer stop()
+
+	client := NewUdpClient(addr.IP.String(), addr.Port, 1)
+	if client == nil {
+		t.Fatal("NewUdpClient returned nil")
+	}
+	defer client.Close()
+
+	sender := NewCommandSender(client)
+	if err := sender.Send("sim/autopilot/heading_sync"); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+
+	assertReceived(t, received, "cmd|once|sim/autopilot/heading_sync")
+}
+
+// assertReceived waits for a datagram on recv and fails the test if it does
+// not arrive, or does not exactly match want.
+func assertReceived(t *testing.T, recv <-chan []byte, want string) {
+	t.Helper()
+
+	select {
+	case got := <-recv:
+		if string(got) != want {
+			t.Errorf("server received %q, want %q", got, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("server never received a datagram, want %q", want)
+	}
+}